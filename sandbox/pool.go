@@ -0,0 +1,116 @@
+package sandbox
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"sync/atomic"
+)
+
+// Pool manages a fixed set of pre-initialized IsolateBox instances so callers
+// don't have to pick --box-id values themselves or serialize access to them.
+type Pool struct {
+	boxes chan *IsolateBox
+
+	size    int32
+	inUse   int32
+	waiters int32
+
+	cleanupFailures int64
+}
+
+// PoolMetrics is a snapshot of a Pool's current state, suitable for exporting
+// to a metrics system.
+type PoolMetrics struct {
+	Size            int
+	InUse           int
+	Waiters         int
+	CleanupFailures int64
+}
+
+// NewPool initializes size boxes starting at startBoxID (startBoxID, startBoxID+1, ...).
+// Boxes left behind by a previous run are recovered the same way New does.
+func NewPool(size int, startBoxID int) (*Pool, error) {
+	p := &Pool{
+		boxes: make(chan *IsolateBox, size),
+		size:  int32(size),
+	}
+
+	for i := 0; i < size; i++ {
+		box, err := New(startBoxID + i)
+		if err != nil {
+			p.Close()
+			return nil, fmt.Errorf("initializing box %d: %w", startBoxID+i, err)
+		}
+		p.boxes <- box
+	}
+
+	return p, nil
+}
+
+// Acquire blocks until a box is available or ctx is done.
+func (p *Pool) Acquire(ctx context.Context) (*IsolateBox, error) {
+	atomic.AddInt32(&p.waiters, 1)
+	defer atomic.AddInt32(&p.waiters, -1)
+
+	select {
+	case box := <-p.boxes:
+		atomic.AddInt32(&p.inUse, 1)
+		return box, nil
+	case <-ctx.Done():
+		return nil, ctx.Err()
+	}
+}
+
+// Release resets box and returns it to the pool for reuse. Callers must not
+// use box again after calling Release.
+//
+// If the reset fails, box's /box contents were never confirmed wiped, so it
+// is never recycled as-is (that would leak one caller's files to the next).
+// Release instead reinitializes the same --box-id from scratch via New, or
+// drops it from the pool entirely if that also fails.
+func (p *Pool) Release(box *IsolateBox) {
+	defer atomic.AddInt32(&p.inUse, -1)
+
+	if err := box.reset(); err != nil {
+		atomic.AddInt64(&p.cleanupFailures, 1)
+		log.Printf("pool: failed to reset box %d, reinitializing it instead of recycling: %v", box.boxID, err)
+
+		fresh, err := New(box.boxID)
+		if err != nil {
+			atomic.AddInt32(&p.size, -1)
+			log.Printf("pool: failed to reinitialize box %d after a failed reset, dropping it from rotation: %v", box.boxID, err)
+			return
+		}
+		box = fresh
+	}
+
+	p.boxes <- box
+}
+
+// Metrics returns a snapshot of the pool's current state.
+func (p *Pool) Metrics() PoolMetrics {
+	return PoolMetrics{
+		Size:            int(atomic.LoadInt32(&p.size)),
+		InUse:           int(atomic.LoadInt32(&p.inUse)),
+		Waiters:         int(atomic.LoadInt32(&p.waiters)),
+		CleanupFailures: atomic.LoadInt64(&p.cleanupFailures),
+	}
+}
+
+// Close fully destroys every box currently idle in the pool. Callers should
+// Acquire/Release all in-flight boxes before calling Close, otherwise boxes
+// still on loan are leaked rather than destroyed.
+func (p *Pool) Close() error {
+	var firstErr error
+	for {
+		select {
+		case box := <-p.boxes:
+			if err := box.Close(); err != nil && firstErr == nil {
+				firstErr = err
+			}
+		default:
+			return firstErr
+		}
+	}
+}
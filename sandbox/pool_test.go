@@ -0,0 +1,140 @@
+package sandbox
+
+import (
+	"context"
+	"errors"
+	"os"
+	"testing"
+)
+
+// fakeIsolate writes a tiny shell script standing in for the real isolate
+// binary, so IsolateBox/Pool plumbing can be tested without it installed.
+func fakeIsolate(t *testing.T, script string) string {
+	t.Helper()
+
+	f, err := os.CreateTemp(t.TempDir(), "fake-isolate-*")
+	if err != nil {
+		t.Fatalf("create fake isolate: %v", err)
+	}
+	if _, err := f.WriteString("#!/bin/sh\n" + script); err != nil {
+		t.Fatalf("write fake isolate: %v", err)
+	}
+	if err := f.Close(); err != nil {
+		t.Fatalf("close fake isolate: %v", err)
+	}
+	if err := os.Chmod(f.Name(), 0o755); err != nil {
+		t.Fatalf("chmod fake isolate: %v", err)
+	}
+	return f.Name()
+}
+
+func TestPoolAcquireReturnsAvailableBox(t *testing.T) {
+	box := &IsolateBox{boxID: 3}
+	p := &Pool{boxes: make(chan *IsolateBox, 1), size: 1}
+	p.boxes <- box
+
+	got, err := p.Acquire(context.Background())
+	if err != nil {
+		t.Fatalf("Acquire: %v", err)
+	}
+	if got != box {
+		t.Fatalf("Acquire returned the wrong box")
+	}
+	if m := p.Metrics(); m.InUse != 1 {
+		t.Fatalf("expected InUse=1, got %d", m.InUse)
+	}
+}
+
+func TestPoolAcquireRespectsContextCancellation(t *testing.T) {
+	p := &Pool{boxes: make(chan *IsolateBox)} // no boxes available
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+
+	if _, err := p.Acquire(ctx); !errors.Is(err, context.Canceled) {
+		t.Fatalf("expected context.Canceled, got %v", err)
+	}
+	if m := p.Metrics(); m.Waiters != 0 {
+		t.Fatalf("expected waiters to settle back to 0, got %d", m.Waiters)
+	}
+}
+
+func TestPoolReleaseRecyclesHealthyBox(t *testing.T) {
+	dir := t.TempDir()
+	IsolatePath = fakeIsolate(t, "echo "+dir+"\nexit 0\n")
+
+	box := &IsolateBox{boxID: 1, path: dir}
+	p := &Pool{boxes: make(chan *IsolateBox, 1), size: 1, inUse: 1}
+
+	p.Release(box)
+
+	if m := p.Metrics(); m.InUse != 0 || m.CleanupFailures != 0 {
+		t.Fatalf("unexpected metrics after a clean release: %+v", m)
+	}
+	select {
+	case got := <-p.boxes:
+		if got != box {
+			t.Fatalf("expected the same box to be recycled")
+		}
+	default:
+		t.Fatal("expected a box back in the pool")
+	}
+}
+
+func TestPoolReleaseReinitializesBoxOnResetFailure(t *testing.T) {
+	dir := t.TempDir()
+	// Fail --cleanup (the first step of reset) but let --init (used by both
+	// reset and New) succeed, so we can tell a recycled box apart from a
+	// freshly reinitialized one.
+	script := `
+for arg in "$@"; do
+  if [ "$arg" = "--cleanup" ]; then
+    exit 1
+  fi
+done
+echo ` + dir + `
+exit 0
+`
+	IsolatePath = fakeIsolate(t, script)
+
+	box := &IsolateBox{boxID: 7, path: dir}
+	p := &Pool{boxes: make(chan *IsolateBox, 1), size: 1, inUse: 1}
+
+	p.Release(box)
+
+	if m := p.Metrics(); m.CleanupFailures != 1 {
+		t.Fatalf("expected 1 cleanup failure, got %d", m.CleanupFailures)
+	}
+
+	select {
+	case got := <-p.boxes:
+		if got == box {
+			t.Fatal("a box whose reset failed was recycled as-is")
+		}
+		if got.boxID != box.boxID {
+			t.Fatalf("replacement box has the wrong id: got %d, want %d", got.boxID, box.boxID)
+		}
+	default:
+		t.Fatal("expected a freshly reinitialized box in the pool")
+	}
+}
+
+func TestPoolReleaseDropsBoxFromRotationWhenReinitFails(t *testing.T) {
+	dir := t.TempDir()
+	// Every invocation fails, so both reset and the New fallback fail.
+	IsolatePath = fakeIsolate(t, "exit 1\n")
+
+	box := &IsolateBox{boxID: 9, path: dir}
+	p := &Pool{boxes: make(chan *IsolateBox, 1), size: 1, inUse: 1}
+
+	p.Release(box)
+
+	if m := p.Metrics(); m.Size != 0 {
+		t.Fatalf("expected pool size to shrink to 0, got %d", m.Size)
+	}
+	select {
+	case <-p.boxes:
+		t.Fatal("no box should have been returned to the pool")
+	default:
+	}
+}
@@ -140,18 +140,49 @@ func (b *IsolateBox) getFilePath(boxpath string) string {
 	return path.Join(b.path, boxpath)
 }
 
+// Close fully destroys the box. Use this when the box will never be used
+// again; a box returned to a Pool is reset instead (see reset).
 func (b *IsolateBox) Close() error {
 	b.mu.Lock()
 	defer b.mu.Unlock()
 	return exec.Command(IsolatePath, "--cg", "--box-id="+strconv.Itoa(b.boxID), "--cleanup").Run()
 }
 
-func (b *IsolateBox) runCommand(ctx context.Context, params []string, metaFile *os.File) (*RunStats, error) {
+// reset wipes the box's filesystem and reinitializes it in place so it can
+// be handed to another caller, without destroying the --box-id itself. It's
+// what Pool.Release uses between leases.
+func (b *IsolateBox) reset() error {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	if err := exec.Command(IsolatePath, "--cg", "--box-id="+strconv.Itoa(b.boxID), "--cleanup").Run(); err != nil {
+		return fmt.Errorf("cleanup box %d: %w", b.boxID, err)
+	}
+
+	ret, err := exec.Command(IsolatePath, "--cg", "--box-id="+strconv.Itoa(b.boxID), "--init").CombinedOutput()
+	if err != nil {
+		return fmt.Errorf("reinit box %d: %w", b.boxID, err)
+	}
+	b.path = strings.TrimSpace(string(ret))
+	return nil
+}
+
+func (b *IsolateBox) runCommand(ctx context.Context, conf *RunConfig, params []string, metaFile *os.File) (*RunStats, error) {
+	unmountScratch, err := b.mountScratch(conf.Scratch)
+	if err != nil {
+		return nil, err
+	}
+	defer func() {
+		if err := unmountScratch(); err != nil {
+			log.Printf("Could not unmount scratch dir for box %d: %v", b.boxID, err)
+		}
+	}()
+
 	var isolateOut bytes.Buffer
 	cmd := exec.CommandContext(ctx, IsolatePath, params...)
 	cmd.Stdout = &isolateOut
 	cmd.Stderr = &isolateOut
-	err := cmd.Run()
+	err = cmd.Run()
 	if _, ok := err.(*exec.ExitError); err != nil && !ok {
 		return nil, err
 	}
@@ -159,10 +190,14 @@ func (b *IsolateBox) runCommand(ctx context.Context, params []string, metaFile *
 	// read Meta File
 	defer metaFile.Close()
 	defer os.Remove(metaFile.Name())
-	return parseMetaFile(metaFile, isolateOut), nil
+	return parseMetaFile(metaFile, isolateOut, conf), nil
 }
 
 func (b *IsolateBox) RunCommand(ctx context.Context, command []string, conf *RunConfig) (*RunStats, error) {
+	if err := b.ValidateConfig(conf); err != nil {
+		return nil, err
+	}
+
 	b.mu.Lock()
 	defer b.mu.Unlock()
 
@@ -187,8 +222,13 @@ func (b *IsolateBox) RunCommand(ctx context.Context, command []string, conf *Run
 			continue
 		}
 		defer os.Remove(metaFile.Name())
-		meta, err = b.runCommand(ctx, append(b.buildRunFlags(conf, metaFile), command...), metaFile)
-		if err == nil && meta != nil && meta.Status != "XX" {
+		meta, err = b.runCommand(ctx, conf, append(b.buildRunFlags(conf, metaFile), command...), metaFile)
+		var scratchErr *ScratchMountError
+		if errors.As(err, &scratchErr) {
+			// Retrying won't fix a missing capability.
+			return nil, err
+		}
+		if err == nil && meta != nil && meta.Verdict != VerdictInternalError {
 			if meta.ExitCode == 127 {
 				if strings.Contains(meta.InternalMessage, "execve") { // It's text file busy, most likely...
 					time.Sleep(runErrTimeout)
@@ -251,8 +291,10 @@ func IsolateVersion() string {
 	return strings.TrimPrefix(string(line), "The process isolator ")
 }
 
-// parseMetaFile parses a specified meta file
-func parseMetaFile(r io.Reader, out bytes.Buffer) *RunStats {
+// parseMetaFile parses a specified meta file. conf is the RunConfig the run
+// was launched with, used to tell a real time-limit kill apart from a
+// wall-time kill; it may be nil.
+func parseMetaFile(r io.Reader, out bytes.Buffer, conf *RunConfig) *RunStats {
 	if r == nil {
 		return nil
 	}
@@ -283,9 +325,12 @@ func parseMetaFile(r io.Reader, out bytes.Buffer) *RunStats {
 		case "time":
 			file.Time, _ = strconv.ParseFloat(val, 64)
 		case "time-wall":
-			// file.WallTime, _ = strconv.ParseFloat(val, 32)
-			continue
-		case "max-rss", "csw-voluntary", "csw-forced", "cg-enabled", "cg-oom-killed":
+			file.WallTime, _ = strconv.ParseFloat(val, 64)
+		case "max-rss":
+			file.MaxRSS, _ = strconv.Atoi(val)
+		case "cg-oom-killed":
+			file.OOMKilled = val == "1"
+		case "csw-voluntary", "csw-forced", "cg-enabled":
 			continue
 		default:
 			log.Printf("Unknown isolate stat: %q (value: %v)", key, val)
@@ -293,6 +338,8 @@ func parseMetaFile(r io.Reader, out bytes.Buffer) *RunStats {
 		}
 	}
 
+	file.Verdict = verdictFromMeta(file.Status, file.OOMKilled, file.WallTime, conf)
+
 	return file
 }
 
@@ -315,6 +362,9 @@ type RunConfig struct {
 	EnvToSet     map[string]string
 
 	Directories []Directory
+
+	// Scratch, if set, mounts a tmpfs over /box for the duration of the run.
+	Scratch *Scratch
 }
 
 // Directory represents a directory rule
@@ -336,12 +386,18 @@ type RunStats struct {
 	Killed     bool `json:"killed"`
 
 	Message string `json:"message"`
-	Status  string `json:"status"`
+	// Status is the raw two-letter isolate status code ("", "TO", "SG", "RE", "XX").
+	// Kept for backward compatibility; prefer Verdict.
+	Status string `json:"status"`
+
+	Time     float64 `json:"time"`
+	WallTime float64 `json:"wall_time"`
+	MaxRSS   int     `json:"max_rss"`
 
-	Time float64 `json:"time"`
+	OOMKilled bool    `json:"oom_killed"`
+	Verdict   Verdict `json:"verdict"`
 
 	InternalMessage string `json:"internal_msg"`
-	// WallTime float64 `json:"wall_time"`
 }
 
 func readFile(p string, w io.Writer) error {
@@ -0,0 +1,161 @@
+package sandbox
+
+import (
+	"errors"
+	"fmt"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"syscall"
+)
+
+// Scratch mounts a tmpfs-backed scratch directory over the box's /box working
+// directory for the duration of a single run. isolate doesn't enforce a disk
+// space limit via cgroups, so this gives callers a hard cap on what a run can
+// write. It does not clear /box between runs: everything in /box, including
+// what the run wrote, ends up back on the box's real filesystem afterwards
+// (see below).
+//
+// Mounting happens after the caller's WriteFile calls but before isolate is
+// launched, so mountScratch stages whatever is already in /box (the program
+// and input files WriteFile put there) and restores it into the new tmpfs -
+// callers can keep writing to /box before RunCommand exactly as they do
+// today. Symmetrically, whatever the run wrote (e.g. prog.out) is staged back
+// out of the tmpfs and restored onto the box's real filesystem before the
+// unmount, so ReadFile after RunCommand returns still works.
+type Scratch struct {
+	// Size is passed straight through as tmpfs's "size=" mount option, e.g. "64M".
+	// Leave empty to use the kernel's default tmpfs size.
+	Size string
+}
+
+// ScratchMountError indicates the scratch tmpfs could not be mounted, most
+// often because the process lacks CAP_SYS_ADMIN. Unlike the transient isolate
+// failures RunCommand retries, retrying this won't help.
+type ScratchMountError struct {
+	Err error
+}
+
+func (e *ScratchMountError) Error() string {
+	return e.Err.Error()
+}
+
+func (e *ScratchMountError) Unwrap() error {
+	return e.Err
+}
+
+// mountScratch mounts conf's scratch tmpfs, if any, returning a cleanup func
+// that unmounts it. The returned func is always safe to call, even if no
+// mount happened.
+func (b *IsolateBox) mountScratch(s *Scratch) (func() error, error) {
+	noop := func() error { return nil }
+	if s == nil {
+		return noop, nil
+	}
+
+	dir := b.getFilePath("/box")
+
+	staged, err := os.MkdirTemp("", "sandbox-scratch-*")
+	if err != nil {
+		return noop, fmt.Errorf("stage scratch contents for box %d: %w", b.boxID, err)
+	}
+	defer os.RemoveAll(staged)
+	if err := copyDirContents(dir, staged); err != nil {
+		return noop, fmt.Errorf("stage scratch contents for box %d: %w", b.boxID, err)
+	}
+
+	opts := "mode=0700"
+	if s.Size != "" {
+		opts += ",size=" + s.Size
+	}
+
+	if err := syscall.Mount("tmpfs", dir, "tmpfs", 0, opts); err != nil {
+		if errors.Is(err, syscall.EPERM) {
+			return noop, &ScratchMountError{Err: fmt.Errorf("mount scratch tmpfs on box %d: missing CAP_SYS_ADMIN: %w", b.boxID, err)}
+		}
+		return noop, &ScratchMountError{Err: fmt.Errorf("mount scratch tmpfs on box %d: %w", b.boxID, err)}
+	}
+
+	if err := os.Chmod(dir, 0700); err != nil {
+		_ = syscall.Unmount(dir, 0)
+		return noop, fmt.Errorf("chmod scratch dir on box %d: %w", b.boxID, err)
+	}
+
+	if err := copyDirContents(staged, dir); err != nil {
+		_ = syscall.Unmount(dir, 0)
+		return noop, fmt.Errorf("restore scratch contents for box %d: %w", b.boxID, err)
+	}
+
+	return func() error {
+		return b.unmountScratch(dir)
+	}, nil
+}
+
+// unmountScratch stages whatever the run wrote into the tmpfs back onto the
+// box's real filesystem before unmounting, so a caller's ReadFile after
+// RunCommand returns still sees the run's output (e.g. prog.out).
+func (b *IsolateBox) unmountScratch(dir string) error {
+	out, err := os.MkdirTemp("", "sandbox-scratch-out-*")
+	if err != nil {
+		return fmt.Errorf("stage scratch output for box %d: %w", b.boxID, err)
+	}
+	defer os.RemoveAll(out)
+
+	if err := copyDirContents(dir, out); err != nil {
+		return fmt.Errorf("stage scratch output for box %d: %w", b.boxID, err)
+	}
+
+	if err := syscall.Unmount(dir, 0); err != nil {
+		return fmt.Errorf("unmount scratch tmpfs on box %d: %w", b.boxID, err)
+	}
+
+	if err := copyDirContents(out, dir); err != nil {
+		return fmt.Errorf("restore scratch output for box %d: %w", b.boxID, err)
+	}
+
+	return nil
+}
+
+// copyDirContents recursively copies src's entries into dst, which must
+// already exist.
+func copyDirContents(src, dst string) error {
+	entries, err := os.ReadDir(src)
+	if err != nil {
+		return err
+	}
+
+	for _, entry := range entries {
+		srcPath := filepath.Join(src, entry.Name())
+		dstPath := filepath.Join(dst, entry.Name())
+
+		info, err := entry.Info()
+		if err != nil {
+			return err
+		}
+
+		if entry.IsDir() {
+			if err := os.MkdirAll(dstPath, info.Mode()); err != nil {
+				return err
+			}
+			if err := copyDirContents(srcPath, dstPath); err != nil {
+				return err
+			}
+			continue
+		}
+
+		if err := copyFile(srcPath, dstPath, info.Mode()); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+func copyFile(src, dst string, mode fs.FileMode) error {
+	in, err := os.Open(src)
+	if err != nil {
+		return err
+	}
+	defer in.Close()
+	return writeFile(dst, in, mode)
+}
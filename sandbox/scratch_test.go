@@ -0,0 +1,118 @@
+package sandbox
+
+import (
+	"errors"
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+func TestCopyDirContentsFlat(t *testing.T) {
+	src := t.TempDir()
+	dst := t.TempDir()
+
+	if err := os.WriteFile(filepath.Join(src, "a.txt"), []byte("hello"), 0o644); err != nil {
+		t.Fatalf("write src file: %v", err)
+	}
+
+	if err := copyDirContents(src, dst); err != nil {
+		t.Fatalf("copyDirContents: %v", err)
+	}
+
+	got, err := os.ReadFile(filepath.Join(dst, "a.txt"))
+	if err != nil {
+		t.Fatalf("read copied file: %v", err)
+	}
+	if string(got) != "hello" {
+		t.Fatalf("got %q, want %q", got, "hello")
+	}
+}
+
+func TestCopyDirContentsNested(t *testing.T) {
+	src := t.TempDir()
+	dst := t.TempDir()
+
+	if err := os.MkdirAll(filepath.Join(src, "sub"), 0o755); err != nil {
+		t.Fatalf("mkdir: %v", err)
+	}
+	if err := os.WriteFile(filepath.Join(src, "sub", "b.txt"), []byte("nested"), 0o644); err != nil {
+		t.Fatalf("write src file: %v", err)
+	}
+
+	if err := copyDirContents(src, dst); err != nil {
+		t.Fatalf("copyDirContents: %v", err)
+	}
+
+	got, err := os.ReadFile(filepath.Join(dst, "sub", "b.txt"))
+	if err != nil {
+		t.Fatalf("read copied nested file: %v", err)
+	}
+	if string(got) != "nested" {
+		t.Fatalf("got %q, want %q", got, "nested")
+	}
+}
+
+func TestCopyDirContentsOverwritesExisting(t *testing.T) {
+	src := t.TempDir()
+	dst := t.TempDir()
+
+	if err := os.WriteFile(filepath.Join(dst, "a.txt"), []byte("stale"), 0o644); err != nil {
+		t.Fatalf("write dst file: %v", err)
+	}
+	if err := os.WriteFile(filepath.Join(src, "a.txt"), []byte("fresh"), 0o644); err != nil {
+		t.Fatalf("write src file: %v", err)
+	}
+
+	if err := copyDirContents(src, dst); err != nil {
+		t.Fatalf("copyDirContents: %v", err)
+	}
+
+	got, err := os.ReadFile(filepath.Join(dst, "a.txt"))
+	if err != nil {
+		t.Fatalf("read copied file: %v", err)
+	}
+	if string(got) != "fresh" {
+		t.Fatalf("got %q, want %q", got, "fresh")
+	}
+}
+
+func TestCopyDirContentsMissingSource(t *testing.T) {
+	dst := t.TempDir()
+	if err := copyDirContents(filepath.Join(dst, "does-not-exist"), dst); err == nil {
+		t.Fatal("expected an error for a missing source directory")
+	}
+}
+
+func TestCopyFile(t *testing.T) {
+	dir := t.TempDir()
+	src := filepath.Join(dir, "src.txt")
+	dst := filepath.Join(dir, "dst.txt")
+
+	if err := os.WriteFile(src, []byte("payload"), 0o644); err != nil {
+		t.Fatalf("write src: %v", err)
+	}
+
+	if err := copyFile(src, dst, 0o644); err != nil {
+		t.Fatalf("copyFile: %v", err)
+	}
+
+	got, err := os.ReadFile(dst)
+	if err != nil {
+		t.Fatalf("read dst: %v", err)
+	}
+	if string(got) != "payload" {
+		t.Fatalf("got %q, want %q", got, "payload")
+	}
+}
+
+func TestScratchMountErrorUnwraps(t *testing.T) {
+	inner := errors.New("boom")
+	err := &ScratchMountError{Err: inner}
+
+	if !errors.Is(err, inner) {
+		t.Fatalf("expected errors.Is to see through ScratchMountError to %v", inner)
+	}
+	if err.Error() != inner.Error() {
+		t.Fatalf("got %q, want %q", err.Error(), inner.Error())
+	}
+}
@@ -0,0 +1,117 @@
+package sandbox
+
+import (
+	"errors"
+	"fmt"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"strings"
+)
+
+// SymlinkAllowList restricts the hosts paths a Directory.In source may resolve
+// to through a symlink. An empty list means no restriction.
+var SymlinkAllowList []string
+
+// validMountOpts are the isolate --dir option tokens we know how to handle.
+var validMountOpts = map[string]bool{
+	"rw":     true,
+	"dev":    true,
+	"noexec": true,
+	"maybe":  true,
+	"fs":     true,
+	"tmp":    true,
+}
+
+// ConfigError reports that a RunConfig was rejected before isolate was ever
+// launched, as opposed to the sandboxed program itself failing.
+type ConfigError struct {
+	Directory string
+	Err       error
+}
+
+func (e *ConfigError) Error() string {
+	return fmt.Sprintf("invalid directory rule for %q: %v", e.Directory, e.Err)
+}
+
+func (e *ConfigError) Unwrap() error {
+	return e.Err
+}
+
+// ValidateConfig checks that every Directory rule in c points at something
+// isolate can actually bind, catching mistakes that would otherwise surface
+// as an opaque isolate failure once the box is already running.
+func (b *IsolateBox) ValidateConfig(c *RunConfig) error {
+	for _, dir := range c.Directories {
+		if dir.Removes {
+			continue
+		}
+
+		if dir.Opts != "" {
+			for _, opt := range strings.Split(dir.Opts, ":") {
+				if !validMountOpts[opt] {
+					return &ConfigError{Directory: dir.In, Err: fmt.Errorf("unknown mount option %q", opt)}
+				}
+			}
+		}
+
+		hostSrc := dir.Out
+		if hostSrc == "" {
+			if dir.Verbatim {
+				// No explicit host mapping; isolate applies its own default rule.
+				continue
+			}
+			hostSrc = dir.In
+		}
+
+		if err := validateDirectorySource(hostSrc); err != nil {
+			return &ConfigError{Directory: dir.In, Err: err}
+		}
+	}
+
+	return nil
+}
+
+// validateDirectorySource mirrors the tolerant checks a context-directory
+// validator (like Docker's) does on a bind-mount source.
+func validateDirectorySource(p string) error {
+	info, err := os.Lstat(p)
+	if err != nil {
+		if errors.Is(err, fs.ErrNotExist) {
+			return fmt.Errorf("source %q does not exist: %w", p, err)
+		}
+		if os.IsPermission(err) {
+			return fmt.Errorf("source %q is not readable: %w", p, err)
+		}
+		return fmt.Errorf("stat source %q: %w", p, err)
+	}
+
+	if info.Mode()&fs.ModeSymlink == 0 {
+		return nil
+	}
+
+	target, err := filepath.EvalSymlinks(p)
+	if err != nil {
+		if errors.Is(err, fs.ErrNotExist) {
+			// Dangling symlink: tolerate it, same as a context-directory validator would.
+			return nil
+		}
+		return fmt.Errorf("resolve symlink %q: %w", p, err)
+	}
+
+	if len(SymlinkAllowList) > 0 && !withinAllowList(target, SymlinkAllowList) {
+		return fmt.Errorf("symlink %q resolves to %q, which is outside the allowed roots", p, target)
+	}
+
+	return nil
+}
+
+func withinAllowList(target string, allowList []string) bool {
+	for _, root := range allowList {
+		root = strings.TrimSuffix(root, "/")
+		if target == root || strings.HasPrefix(target, root+"/") {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,95 @@
+package sandbox
+
+import (
+	"errors"
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+func TestValidateDirectorySourceMissing(t *testing.T) {
+	err := validateDirectorySource(filepath.Join(t.TempDir(), "does-not-exist"))
+	if err == nil {
+		t.Fatal("expected an error for a missing source")
+	}
+}
+
+func TestValidateDirectorySourceOK(t *testing.T) {
+	if err := validateDirectorySource(t.TempDir()); err != nil {
+		t.Fatalf("unexpected error for an existing directory: %v", err)
+	}
+}
+
+func TestValidateDirectorySourceDanglingSymlinkTolerated(t *testing.T) {
+	dir := t.TempDir()
+	link := filepath.Join(dir, "dangling")
+	if err := os.Symlink(filepath.Join(dir, "missing-target"), link); err != nil {
+		t.Fatalf("symlink: %v", err)
+	}
+
+	if err := validateDirectorySource(link); err != nil {
+		t.Fatalf("a dangling symlink should be tolerated, got: %v", err)
+	}
+}
+
+func TestValidateDirectorySourceSymlinkOutsideAllowList(t *testing.T) {
+	dir := t.TempDir()
+	target := filepath.Join(dir, "target")
+	if err := os.Mkdir(target, 0o755); err != nil {
+		t.Fatalf("mkdir: %v", err)
+	}
+	link := filepath.Join(dir, "link")
+	if err := os.Symlink(target, link); err != nil {
+		t.Fatalf("symlink: %v", err)
+	}
+
+	old := SymlinkAllowList
+	SymlinkAllowList = []string{filepath.Join(dir, "somewhere-else")}
+	defer func() { SymlinkAllowList = old }()
+
+	if err := validateDirectorySource(link); err == nil {
+		t.Fatal("expected a symlink outside the allow-list to be rejected")
+	}
+}
+
+func TestValidateDirectorySourceSymlinkWithinAllowList(t *testing.T) {
+	dir := t.TempDir()
+	target := filepath.Join(dir, "target")
+	if err := os.Mkdir(target, 0o755); err != nil {
+		t.Fatalf("mkdir: %v", err)
+	}
+	link := filepath.Join(dir, "link")
+	if err := os.Symlink(target, link); err != nil {
+		t.Fatalf("symlink: %v", err)
+	}
+
+	old := SymlinkAllowList
+	SymlinkAllowList = []string{dir}
+	defer func() { SymlinkAllowList = old }()
+
+	if err := validateDirectorySource(link); err != nil {
+		t.Fatalf("a symlink within the allow-list should be accepted, got: %v", err)
+	}
+}
+
+func TestValidateConfigRejectsUnknownMountOpt(t *testing.T) {
+	b := &IsolateBox{}
+	err := b.ValidateConfig(&RunConfig{
+		Directories: []Directory{{In: t.TempDir(), Opts: "bogus"}},
+	})
+
+	var cerr *ConfigError
+	if !errors.As(err, &cerr) {
+		t.Fatalf("expected a *ConfigError, got %v", err)
+	}
+}
+
+func TestValidateConfigSkipsRemovesRules(t *testing.T) {
+	b := &IsolateBox{}
+	err := b.ValidateConfig(&RunConfig{
+		Directories: []Directory{{In: "/does/not/exist", Removes: true}},
+	})
+	if err != nil {
+		t.Fatalf("a Removes rule should skip source validation, got: %v", err)
+	}
+}
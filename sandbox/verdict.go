@@ -0,0 +1,103 @@
+package sandbox
+
+import (
+	"encoding/json"
+	"fmt"
+)
+
+// Verdict is a typed classification of a run's outcome, computed from the
+// isolate meta file so callers don't have to string-match RunStats.Status
+// themselves.
+type Verdict int
+
+const (
+	VerdictOK Verdict = iota
+	VerdictTimeLimit
+	VerdictWallTimeLimit
+	VerdictMemoryLimit
+	VerdictRuntimeError
+	VerdictSignaled
+	VerdictInternalError
+)
+
+func (v Verdict) String() string {
+	switch v {
+	case VerdictOK:
+		return "ok"
+	case VerdictTimeLimit:
+		return "time_limit"
+	case VerdictWallTimeLimit:
+		return "wall_time_limit"
+	case VerdictMemoryLimit:
+		return "memory_limit"
+	case VerdictRuntimeError:
+		return "runtime_error"
+	case VerdictSignaled:
+		return "signaled"
+	case VerdictInternalError:
+		return "internal_error"
+	default:
+		return "unknown"
+	}
+}
+
+// MarshalJSON encodes a Verdict as its String() name rather than the raw
+// int, so JSON consumers get the same names in-process callers do.
+func (v Verdict) MarshalJSON() ([]byte, error) {
+	return json.Marshal(v.String())
+}
+
+// UnmarshalJSON is the inverse of MarshalJSON.
+func (v *Verdict) UnmarshalJSON(data []byte) error {
+	var s string
+	if err := json.Unmarshal(data, &s); err != nil {
+		return err
+	}
+
+	switch s {
+	case "ok":
+		*v = VerdictOK
+	case "time_limit":
+		*v = VerdictTimeLimit
+	case "wall_time_limit":
+		*v = VerdictWallTimeLimit
+	case "memory_limit":
+		*v = VerdictMemoryLimit
+	case "runtime_error":
+		*v = VerdictRuntimeError
+	case "signaled":
+		*v = VerdictSignaled
+	case "internal_error":
+		*v = VerdictInternalError
+	default:
+		return fmt.Errorf("sandbox: unknown verdict %q", s)
+	}
+	return nil
+}
+
+// verdictFromMeta classifies a run based on the raw isolate status plus the
+// extra signals the meta file gives us. conf may be nil, in which case the
+// TO/wall-time distinction falls back to VerdictTimeLimit.
+func verdictFromMeta(status string, oomKilled bool, wallTime float64, conf *RunConfig) Verdict {
+	if oomKilled {
+		return VerdictMemoryLimit
+	}
+
+	switch status {
+	case "":
+		return VerdictOK
+	case "TO":
+		if conf != nil && conf.WallTimeLimit != 0 && wallTime >= conf.WallTimeLimit {
+			return VerdictWallTimeLimit
+		}
+		return VerdictTimeLimit
+	case "SG":
+		return VerdictSignaled
+	case "RE":
+		return VerdictRuntimeError
+	case "XX":
+		return VerdictInternalError
+	default:
+		return VerdictInternalError
+	}
+}
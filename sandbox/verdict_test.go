@@ -0,0 +1,58 @@
+package sandbox
+
+import "testing"
+
+func TestVerdictFromMeta(t *testing.T) {
+	cases := []struct {
+		name      string
+		status    string
+		oomKilled bool
+		wallTime  float64
+		conf      *RunConfig
+		want      Verdict
+	}{
+		{"ok", "", false, 0, nil, VerdictOK},
+		{"time limit, no wall config", "TO", false, 0, nil, VerdictTimeLimit},
+		{"time limit under the wall limit", "TO", false, 1, &RunConfig{WallTimeLimit: 3}, VerdictTimeLimit},
+		{"wall time limit reached", "TO", false, 3, &RunConfig{WallTimeLimit: 3}, VerdictWallTimeLimit},
+		{"signaled", "SG", false, 0, nil, VerdictSignaled},
+		{"oom overrides signaled", "SG", true, 0, nil, VerdictMemoryLimit},
+		{"oom overrides a clean exit", "", true, 0, nil, VerdictMemoryLimit},
+		{"runtime error", "RE", false, 0, nil, VerdictRuntimeError},
+		{"internal error", "XX", false, 0, nil, VerdictInternalError},
+		{"unknown status", "??", false, 0, nil, VerdictInternalError},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			got := verdictFromMeta(tc.status, tc.oomKilled, tc.wallTime, tc.conf)
+			if got != tc.want {
+				t.Errorf("verdictFromMeta(%q, %v, %v, %v) = %v, want %v", tc.status, tc.oomKilled, tc.wallTime, tc.conf, got, tc.want)
+			}
+		})
+	}
+}
+
+func TestVerdictJSONRoundTrip(t *testing.T) {
+	for v := VerdictOK; v <= VerdictInternalError; v++ {
+		data, err := v.MarshalJSON()
+		if err != nil {
+			t.Fatalf("MarshalJSON(%v): %v", v, err)
+		}
+
+		var got Verdict
+		if err := got.UnmarshalJSON(data); err != nil {
+			t.Fatalf("UnmarshalJSON(%s): %v", data, err)
+		}
+		if got != v {
+			t.Errorf("round trip mismatch: got %v, want %v", got, v)
+		}
+	}
+}
+
+func TestVerdictUnmarshalJSONRejectsUnknownName(t *testing.T) {
+	var v Verdict
+	if err := v.UnmarshalJSON([]byte(`"not_a_real_verdict"`)); err == nil {
+		t.Fatal("expected an error for an unknown verdict name")
+	}
+}